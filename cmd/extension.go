@@ -0,0 +1,14 @@
+package cmd
+
+import "github.com/spf13/cobra"
+
+// NewCmdExtension returns the `ksbuilder extension` command group.
+func NewCmdExtension() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "extension",
+		Short: "Manage KubeSphere extensions",
+	}
+	cmd.AddCommand(NewCmdExtensionLint())
+	cmd.AddCommand(NewCmdExtensionInspect())
+	return cmd
+}
@@ -0,0 +1,53 @@
+package cmd
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+
+	"github.com/spf13/cobra"
+
+	"github.com/kubesphere/ksbuilder/pkg/extension"
+	"github.com/kubesphere/ksbuilder/pkg/extension/additions"
+	"github.com/kubesphere/ksbuilder/pkg/helm"
+)
+
+// NewCmdExtensionInspect returns the `ksbuilder extension inspect`
+// subcommand, which prints a chart's additions (README, resolved values,
+// dependency graph) as JSON so downstream tooling, such as the KubeSphere
+// marketplace or CI, can consume them without re-rendering the chart.
+func NewCmdExtensionInspect() *cobra.Command {
+	return &cobra.Command{
+		Use:   "inspect <chart-path>",
+		Short: "Print a chart's README, resolved values, and dependency graph as JSON",
+		Args:  cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			path := args[0]
+
+			ext, err := extension.Load(path)
+			if err != nil {
+				return err
+			}
+			chartYaml, err := ext.Metadata.ToChartYaml()
+			if err != nil {
+				return err
+			}
+			chartRequested, err := helm.Load(path, chartYaml)
+			if err != nil {
+				return err
+			}
+
+			a, err := additions.Extract(chartRequested)
+			if err != nil {
+				return err
+			}
+
+			enc := json.NewEncoder(os.Stdout)
+			enc.SetIndent("", "  ")
+			if err := enc.Encode(a); err != nil {
+				return fmt.Errorf("encode additions: %w", err)
+			}
+			return nil
+		},
+	}
+}
@@ -0,0 +1,68 @@
+package cmd
+
+import (
+	"os"
+
+	"github.com/spf13/cobra"
+	"sigs.k8s.io/yaml"
+
+	"github.com/kubesphere/ksbuilder/cmd/options"
+	"github.com/kubesphere/ksbuilder/pkg/extension"
+)
+
+// NewCmdExtensionLint returns the `ksbuilder extension lint` subcommand.
+func NewCmdExtensionLint() *cobra.Command {
+	o := options.NewLintOptions()
+	var registryAuthFile string
+
+	cmd := &cobra.Command{
+		Use:   "lint <chart-path>...",
+		Short: "Lint a KubeSphere extension chart",
+		Args:  cobra.MinimumNArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if registryAuthFile != "" {
+				auth, err := loadRegistryAuth(registryAuthFile)
+				if err != nil {
+					return err
+				}
+				o.RegistryAuth = auth
+			}
+			return extension.Lint(o, args)
+		},
+	}
+
+	cmd.Flags().BoolVar(&o.Client.Strict, "strict", false, "fail on lint warnings as well as errors")
+	cmd.Flags().BoolVar(&o.Client.WithSubcharts, "with-subcharts", false, "also lint the chart's subcharts")
+	cmd.Flags().BoolVarP(&o.Client.Quiet, "quiet", "q", false, "only print charts with errors or warnings")
+	cmd.Flags().StringArrayVar(&o.ValueOpts.ValueFiles, "values", nil, "specify values in a YAML file")
+	cmd.Flags().StringArrayVar(&o.ValueOpts.Values, "set", nil, "set values on the command line")
+
+	cmd.Flags().BoolVar(&o.CheckImages, "check-images", false, "resolve every declared image against its registry")
+	cmd.Flags().StringSliceVar(&o.Platforms, "platforms", nil, "platforms (os/arch) a declared image's manifest list must cover, e.g. linux/amd64,linux/arm64")
+	cmd.Flags().IntVar(&o.ImageCheckWorkers, "image-check-workers", o.ImageCheckWorkers, "concurrency of the --check-images registry lookups")
+	cmd.Flags().StringVar(&registryAuthFile, "registry-auth", "", "path to a YAML file of per-registry credentials for --check-images, keyed by registry host")
+
+	cmd.Flags().StringVar(&o.Format, "format", o.Format, "report format: text, json, or sarif")
+	cmd.Flags().StringVar(&o.Output, "output", o.Output, "file to write the report to (stdout when unset)")
+	cmd.Flags().StringVar(&o.Severity, "severity", o.Severity, "minimum severity (info, warning, error) that fails the lint")
+
+	return cmd
+}
+
+// loadRegistryAuth reads the --registry-auth YAML file, which maps a
+// registry host to its username/password, e.g.:
+//
+//	registry.example.com:
+//	  username: bot
+//	  password: hunter2
+func loadRegistryAuth(path string) (map[string]options.RegistryCredential, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	auth := map[string]options.RegistryCredential{}
+	if err := yaml.Unmarshal(data, &auth); err != nil {
+		return nil, err
+	}
+	return auth, nil
+}
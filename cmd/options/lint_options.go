@@ -0,0 +1,59 @@
+// Package options holds the flag-backed option structs shared between
+// ksbuilder's cobra commands and the pkg/extension lint implementation.
+package options
+
+import (
+	"helm.sh/helm/v3/pkg/action"
+	"helm.sh/helm/v3/pkg/cli"
+	"helm.sh/helm/v3/pkg/cli/values"
+)
+
+// RegistryCredential is a per-registry basic-auth override for
+// LintOptions.CheckImages; when set for a registry it is used instead of
+// any ~/.docker/config.json credentials for that registry.
+type RegistryCredential struct {
+	Username string
+	Password string
+}
+
+// LintOptions backs `ksbuilder extension lint`.
+type LintOptions struct {
+	Client    *action.Lint
+	ValueOpts *values.Options
+	Settings  *cli.EnvSettings
+
+	// CheckImages, when set, resolves every image declared in
+	// extension.yaml against its registry.
+	CheckImages bool
+	// Platforms is the set of "os/arch" pairs a declared image's manifest
+	// list must cover when CheckImages is set, e.g. "linux/amd64".
+	Platforms []string
+	// ImageCheckWorkers bounds the concurrency of the registry lookups
+	// CheckImages performs.
+	ImageCheckWorkers int
+	// RegistryAuth overrides ~/.docker/config.json credentials per
+	// registry host for CheckImages, keyed by registry host
+	// (e.g. "registry.example.com").
+	RegistryAuth map[string]RegistryCredential
+
+	// Format selects the lint report's rendering: "text" (default),
+	// "json", or "sarif".
+	Format string
+	// Output is the file the report is written to; stdout when empty.
+	Output string
+	// Severity is the floor at or above which a finding causes the lint
+	// to exit non-zero: "info", "warning" (default), or "error".
+	Severity string
+}
+
+// NewLintOptions returns a LintOptions with its defaults set.
+func NewLintOptions() *LintOptions {
+	return &LintOptions{
+		Client:            action.NewLint(),
+		ValueOpts:         &values.Options{},
+		Settings:          cli.New(),
+		ImageCheckWorkers: 4,
+		Format:            "text",
+		Severity:          "warning",
+	}
+}
@@ -0,0 +1,14 @@
+package cmd
+
+import "github.com/spf13/cobra"
+
+// NewCmdRoot returns the root `ksbuilder` command.
+func NewCmdRoot() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "ksbuilder",
+		Short: "A build tool for KubeSphere extensions",
+	}
+	cmd.AddCommand(NewCmdExtension())
+	cmd.AddCommand(NewCmdSchema())
+	return cmd
+}
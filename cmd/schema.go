@@ -0,0 +1,49 @@
+package cmd
+
+import (
+	"os"
+	"path/filepath"
+
+	"github.com/spf13/cobra"
+
+	"github.com/kubesphere/ksbuilder/pkg/extension/schema"
+)
+
+// NewCmdSchema returns the `ksbuilder schema` command group.
+func NewCmdSchema() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "schema",
+		Short: "Manage an extension's values.schema.json",
+	}
+	cmd.AddCommand(newCmdSchemaGenerate())
+	return cmd
+}
+
+func newCmdSchemaGenerate() *cobra.Command {
+	var chartPath string
+	cmd := &cobra.Command{
+		Use:   "generate",
+		Short: "Generate a starter values.schema.json from values.yaml",
+		Long: `Generate walks a chart's default values.yaml, infers a draft-07 JSON
+schema from its structure, and writes it to values.schema.json next to it.
+
+Fields can be enriched with leading comments in values.yaml:
+
+  # +ksbuilder:enum=a,b,c
+  # +ksbuilder:required
+`,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			valuesYAML, err := os.ReadFile(filepath.Join(chartPath, "values.yaml"))
+			if err != nil {
+				return err
+			}
+			out, err := schema.Generate(valuesYAML)
+			if err != nil {
+				return err
+			}
+			return os.WriteFile(filepath.Join(chartPath, "values.schema.json"), out, 0o644)
+		},
+	}
+	cmd.Flags().StringVar(&chartPath, "path", ".", "path to the chart")
+	return cmd
+}
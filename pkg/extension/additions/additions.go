@@ -0,0 +1,150 @@
+// Package additions extracts a chart's "additions" — its README, fully
+// resolved default values, and direct dependency list — mirroring what
+// a chart repository backend needs to render a chart detail page.
+package additions
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/Masterminds/semver/v3"
+	"helm.sh/helm/v3/pkg/chart"
+	"helm.sh/helm/v3/pkg/chartutil"
+)
+
+// Dependency describes one entry in a chart's dependency graph.
+type Dependency struct {
+	Name       string `json:"name"`
+	Version    string `json:"version"`
+	Repository string `json:"repository"`
+	Alias      string `json:"alias,omitempty"`
+	Condition  string `json:"condition,omitempty"`
+	// Resolved reports whether the dependency was actually loaded, i.e.
+	// chart.Dependencies() has an entry for it.
+	Resolved bool `json:"resolved"`
+}
+
+// Additions are the chart "extras" a chart repository detail page needs
+// alongside the chart itself.
+type Additions struct {
+	README       string                 `json:"readme"`
+	Values       map[string]interface{} `json:"values"`
+	Dependencies []Dependency           `json:"dependencies"`
+}
+
+// Extract returns chart's README, fully resolved default values (after
+// chartutil.CoalesceValues), and dependency list. The list holds the
+// dependencies declared in c's own Chart.yaml; the dependencies of its
+// subcharts are not included.
+func Extract(c *chart.Chart) (Additions, error) {
+	values, err := chartutil.CoalesceValues(c, c.Values)
+	if err != nil {
+		return Additions{}, fmt.Errorf("coalesce values: %w", err)
+	}
+
+	return Additions{
+		README:       readme(c),
+		Values:       values,
+		Dependencies: dependencies(c),
+	}, nil
+}
+
+func readme(c *chart.Chart) string {
+	for _, f := range c.Files {
+		if strings.EqualFold(f.Name, "README.md") {
+			return string(f.Data)
+		}
+	}
+	return ""
+}
+
+func dependencies(c *chart.Chart) []Dependency {
+	var deps []Dependency
+	for _, md := range c.Metadata.Dependencies {
+		resolved := false
+		for _, d := range c.Dependencies() {
+			if d.Name() == md.Name {
+				resolved = true
+				break
+			}
+		}
+		deps = append(deps, Dependency{
+			Name:       md.Name,
+			Version:    md.Version,
+			Repository: md.Repository,
+			Alias:      md.Alias,
+			Condition:  md.Condition,
+			Resolved:   resolved,
+		})
+	}
+	return deps
+}
+
+// IsSemverRange reports whether version parses as a valid semver
+// constraint, e.g. "^1.2.3" or ">=1.0.0 <2.0.0".
+func IsSemverRange(version string) bool {
+	_, err := semver.NewConstraint(version)
+	return err == nil
+}
+
+// DependencyConditionDefined reports whether condition — a comma-separated
+// list of dotted value paths, as Helm evaluates them — has at least one
+// path defined in values.
+func DependencyConditionDefined(values map[string]interface{}, condition string) bool {
+	for _, path := range strings.Split(condition, ",") {
+		if hasValuePath(values, strings.TrimSpace(path)) {
+			return true
+		}
+	}
+	return false
+}
+
+func hasValuePath(values map[string]interface{}, path string) bool {
+	if path == "" {
+		return false
+	}
+	cur := values
+	parts := strings.Split(path, ".")
+	for i, part := range parts {
+		v, ok := cur[part]
+		if !ok {
+			return false
+		}
+		if i == len(parts)-1 {
+			return true
+		}
+		next, ok := v.(map[string]interface{})
+		if !ok {
+			return false
+		}
+		cur = next
+	}
+	return true
+}
+
+// UndocumentedValuesKeys returns the dotted paths of every key in values
+// that isn't mentioned anywhere in readme.
+func UndocumentedValuesKeys(values map[string]interface{}, readme string) []string {
+	var undocumented []string
+	for _, key := range flattenKeys(values, "") {
+		if !strings.Contains(readme, key) {
+			undocumented = append(undocumented, key)
+		}
+	}
+	return undocumented
+}
+
+func flattenKeys(values map[string]interface{}, prefix string) []string {
+	var keys []string
+	for k, v := range values {
+		full := k
+		if prefix != "" {
+			full = prefix + "." + k
+		}
+		keys = append(keys, full)
+		if nested, ok := v.(map[string]interface{}); ok {
+			keys = append(keys, flattenKeys(nested, full)...)
+		}
+	}
+	return keys
+}
@@ -0,0 +1,67 @@
+package additions
+
+import "testing"
+
+func TestIsSemverRange(t *testing.T) {
+	cases := map[string]bool{
+		"1.2.3":          true,
+		"^1.2.3":         true,
+		"~1.2.3":         true,
+		">=1.0.0 <2.0.0": true,
+		"latest":         false,
+		"":               false,
+		"main":           false,
+	}
+	for version, want := range cases {
+		if got := IsSemverRange(version); got != want {
+			t.Errorf("IsSemverRange(%q) = %v, want %v", version, got, want)
+		}
+	}
+}
+
+func TestDependencyConditionDefined(t *testing.T) {
+	values := map[string]interface{}{
+		"mysql": map[string]interface{}{
+			"enabled": true,
+		},
+	}
+
+	cases := []struct {
+		condition string
+		want      bool
+	}{
+		{"mysql.enabled", true},
+		{"mysql.enabled,postgresql.enabled", true},
+		{"postgresql.enabled", false},
+		{"postgresql.enabled,redis.enabled", false},
+		{"mysql.missing.deeper", false},
+		{"", false},
+	}
+	for _, c := range cases {
+		if got := DependencyConditionDefined(values, c.condition); got != c.want {
+			t.Errorf("DependencyConditionDefined(%q) = %v, want %v", c.condition, got, c.want)
+		}
+	}
+}
+
+func TestUndocumentedValuesKeys(t *testing.T) {
+	values := map[string]interface{}{
+		"image": map[string]interface{}{
+			"registry": "docker.io",
+			"tag":      "latest",
+		},
+		"replicas": 1,
+	}
+	readme := "Configure image.registry to point at your mirror."
+
+	got := UndocumentedValuesKeys(values, readme)
+	want := map[string]bool{"image": false, "image.registry": false, "image.tag": true, "replicas": true}
+	if len(got) != 2 {
+		t.Fatalf("UndocumentedValuesKeys() = %v, want 2 undocumented keys", got)
+	}
+	for _, key := range got {
+		if !want[key] {
+			t.Errorf("unexpected undocumented key %q", key)
+		}
+	}
+}
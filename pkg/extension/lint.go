@@ -6,6 +6,7 @@ import (
 	"path/filepath"
 	"strings"
 
+	"github.com/google/go-containerregistry/pkg/authn"
 	"helm.sh/helm/v3/pkg/action"
 	"helm.sh/helm/v3/pkg/chart"
 	"helm.sh/helm/v3/pkg/chartutil"
@@ -18,10 +19,66 @@ import (
 	"sigs.k8s.io/yaml"
 
 	"github.com/kubesphere/ksbuilder/cmd/options"
+	"github.com/kubesphere/ksbuilder/pkg/extension/additions"
+	"github.com/kubesphere/ksbuilder/pkg/extension/registry"
+	"github.com/kubesphere/ksbuilder/pkg/extension/report"
+	"github.com/kubesphere/ksbuilder/pkg/extension/schema"
 	"github.com/kubesphere/ksbuilder/pkg/helm"
 )
 
-func WithHelm(o *options.LintOptions, paths []string) error {
+// Lint runs the helm, KubeSphere builtin and schema lint phases against
+// paths, collecting their findings into
+// a single report.Reporter chosen by o.Format, then writes it to o.Output
+// (stdout when unset). It returns an error when the report contains a
+// finding at or above o.Severity, independent of any error returned by the
+// phases themselves.
+func Lint(o *options.LintOptions, paths []string) error {
+	reporter, err := report.New(o.Format)
+	if err != nil {
+		return err
+	}
+	floor, err := report.ParseSeverity(o.Severity)
+	if err != nil {
+		return err
+	}
+
+	// Run every phase unconditionally so a chart that fails plain helm
+	// lint still gets its KubeSphere builtin and schema findings folded
+	// into the same report; only the first phase error is returned.
+	var phaseErr error
+	if err := WithHelm(o, paths, reporter); err != nil {
+		phaseErr = err
+	}
+	if err := WithBuiltins(o, paths, reporter); err != nil && phaseErr == nil {
+		phaseErr = err
+	}
+	if err := WithSchema(o, paths, reporter); err != nil && phaseErr == nil {
+		phaseErr = err
+	}
+
+	out := os.Stdout
+	if o.Output != "" {
+		f, err := os.Create(o.Output)
+		if err != nil {
+			return err
+		}
+		defer f.Close()
+		out = f
+	}
+	if err := reporter.Flush(out); err != nil {
+		return err
+	}
+
+	if phaseErr != nil {
+		return phaseErr
+	}
+	if reporter.HasSeverity(floor) {
+		return fmt.Errorf("lint found findings at or above severity %q", floor)
+	}
+	return nil
+}
+
+func WithHelm(o *options.LintOptions, paths []string, r report.Reporter) error {
 	fmt.Print("\n#################### lint by helm ####################\n")
 	if o.Client.WithSubcharts {
 		for _, p := range paths {
@@ -46,10 +103,7 @@ func WithHelm(o *options.LintOptions, paths []string) error {
 		return err
 	}
 
-	var message strings.Builder
 	failed := 0
-	errorsOrWarnings := 0
-
 	for _, path := range paths {
 		metadata, err := LoadMetadata(paths[0])
 		if err != nil {
@@ -62,57 +116,54 @@ func WithHelm(o *options.LintOptions, paths []string) error {
 
 		result := helm.Lint(o.Client, []string{path}, vals, chartYaml)
 
-		// If there is no errors/warnings and quiet flag is set
-		// go to the next chart
-		hasWarningsOrErrors := action.HasWarningsOrErrors(result)
-		if hasWarningsOrErrors {
-			errorsOrWarnings++
-		}
-		if o.Client.Quiet && !hasWarningsOrErrors {
+		// If there are no errors/warnings and --quiet is set, skip
+		// this chart entirely.
+		if o.Client.Quiet && !action.HasWarningsOrErrors(result) {
+			if len(result.Errors) != 0 {
+				failed++
+			}
 			continue
 		}
 
-		fmt.Fprintf(&message, "==> Linting %s\n", path)
-
 		// All the Errors that are generated by a chart
 		// that failed a lint will be included in the
-		// results.Messages so we only need to print
+		// results.Messages so we only need to report
 		// the Errors if there are no Messages.
 		if len(result.Messages) == 0 {
 			for _, err := range result.Errors {
-				fmt.Fprintf(&message, "Error %s\n", err)
+				r.Report(report.Finding{
+					RuleID:   "helm.lint",
+					Severity: report.SeverityError,
+					File:     path,
+					Message:  err.Error(),
+				})
 			}
 		}
 
 		for _, msg := range result.Messages {
-			if !o.Client.Quiet || msg.Severity > support.InfoSev {
-				fmt.Fprintf(&message, "%s\n", msg)
+			if o.Client.Quiet && msg.Severity <= support.InfoSev {
+				continue
 			}
+			r.Report(report.Finding{
+				RuleID:   "helm.lint",
+				Severity: report.FromHelmSeverity(msg.Severity),
+				File:     path,
+				Message:  msg.Error(),
+			})
 		}
 
 		if len(result.Errors) != 0 {
 			failed++
 		}
-
-		// Adding extra new line here to break up the
-		// results, stops this from being a big wall of
-		// text and makes it easier to follow.
-		fmt.Fprint(&message, "\n")
 	}
 
-	fmt.Print(message.String())
-
-	summary := fmt.Sprintf("%d chart(s) linted, %d chart(s) failed", len(paths), failed)
 	if failed > 0 {
-		return fmt.Errorf(summary)
-	}
-	if !o.Client.Quiet || errorsOrWarnings > 0 {
-		fmt.Print(summary)
+		return fmt.Errorf("%d of %d chart(s) failed helm lint", failed, len(paths))
 	}
 	return nil
 }
 
-func WithBuiltins(paths []string) error {
+func WithBuiltins(o *options.LintOptions, paths []string, r report.Reporter) error {
 	fmt.Print("\n#################### lint by kubesphere ####################\n")
 	ext, err := Load(paths[0])
 	if err != nil {
@@ -127,22 +178,154 @@ func WithBuiltins(paths []string) error {
 		return err
 	}
 
-	if err := lintExtensionsImages(*chartRequested, paths[0], ext.Metadata.Images); err != nil {
+	if err := lintExtensionsImages(o, r, *chartRequested, paths[0], ext.Metadata.Images); err != nil {
+		return err
+	}
+	if err := lintGlobalImageRegistry(r, *chartRequested, paths[0]); err != nil {
 		return err
 	}
-	if err := lintGlobalImageRegistry(*chartRequested, paths[0]); err != nil {
+	if err := lintGlobalNodeSelector(r, *chartRequested, paths[0]); err != nil {
 		return err
 	}
-	if err := lintGlobalNodeSelector(*chartRequested, paths[0]); err != nil {
+	if err := lintAdditions(r, *chartRequested, paths[0]); err != nil {
 		return err
 	}
 	return nil
 }
 
-func lintExtensionsImages(charts chart.Chart, extension string, images []string) error {
+// lintAdditions checks the chart "additions" an extension marketplace needs
+// to render a detail page: that a README exists and documents every
+// values.yaml key, and that each dependency is pinned to a valid semver
+// range with a condition that resolves to a real values key.
+func lintAdditions(r report.Reporter, charts chart.Chart, extension string) error {
+	fmt.Print("\nInfo: lint chart additions\n")
+	a, err := additions.Extract(&charts)
+	if err != nil {
+		return err
+	}
+
+	if strings.TrimSpace(a.README) == "" {
+		r.Report(report.Finding{
+			RuleID:      "additions.readme",
+			Severity:    report.SeverityWarning,
+			File:        extension,
+			Message:     "README.md is missing or empty",
+			Remediation: "add a README.md describing the extension",
+		})
+	}
+
+	for _, dep := range a.Dependencies {
+		if !additions.IsSemverRange(dep.Version) {
+			r.Report(report.Finding{
+				RuleID:      "additions.dependency.version",
+				Severity:    report.SeverityError,
+				File:        extension,
+				Name:        dep.Name,
+				Message:     fmt.Sprintf("dependency %s is pinned to %q, which is not a valid semver range", dep.Name, dep.Version),
+				Remediation: `use a semver range such as "^1.2.3" so the dependency can receive patch updates`,
+			})
+		}
+		if dep.Condition != "" && !additions.DependencyConditionDefined(a.Values, dep.Condition) {
+			r.Report(report.Finding{
+				RuleID:      "additions.dependency.condition",
+				Severity:    report.SeverityError,
+				File:        extension,
+				Name:        dep.Name,
+				Message:     fmt.Sprintf("dependency %s's condition %q references an undefined values key", dep.Name, dep.Condition),
+				Remediation: "add the key to values.yaml, or fix the condition in Chart.yaml",
+			})
+		}
+	}
+
+	// Use the chart's own values.yaml, not a.Values (the fully resolved
+	// tree after CoalesceValues), so subchart defaults like
+	// "mysql.auth.rootPassword" don't get flagged as undocumented — the
+	// extension author has no reason to re-document a dependency's values.
+	for _, key := range additions.UndocumentedValuesKeys(charts.Values, a.README) {
+		r.Report(report.Finding{
+			RuleID:   "additions.values.undocumented",
+			Severity: report.SeverityWarning,
+			File:     extension,
+			Message:  fmt.Sprintf("values.yaml key %q is undocumented in README.md", key),
+		})
+	}
+
+	return nil
+}
+
+// WithSchema validates a chart's values against its values.schema.json,
+// when one is present, using a draft-07 JSON Schema validator. The values
+// are the chart's own values.yaml defaults coalesced with any --set/--values
+// overrides, the same as a template render would see, so a schema with
+// required fields doesn't false-positive on charts that never override
+// them from the CLI. Charts without a values.schema.json are skipped.
+func WithSchema(o *options.LintOptions, paths []string, r report.Reporter) error {
+	fmt.Print("\n#################### lint by schema ####################\n")
+	for _, path := range paths {
+		schemaPath := filepath.Join(path, "values.schema.json")
+		schemaJSON, err := os.ReadFile(schemaPath)
+		if os.IsNotExist(err) {
+			continue
+		}
+		if err != nil {
+			return err
+		}
+
+		metadata, err := LoadMetadata(path)
+		if err != nil {
+			return err
+		}
+		chartYaml, err := metadata.ToChartYaml()
+		if err != nil {
+			return err
+		}
+		chartRequested, err := helm.Load(path, chartYaml)
+		if err != nil {
+			return err
+		}
+
+		vals, err := o.ValueOpts.MergeValues(getter.All(o.Settings))
+		if err != nil {
+			return err
+		}
+		if err := chartutil.ProcessDependenciesWithMerge(chartRequested, vals); err != nil {
+			return err
+		}
+		coalesced, err := chartutil.CoalesceValues(chartRequested, vals)
+		if err != nil {
+			return err
+		}
+
+		violations, err := schema.Validate(schemaJSON, coalesced)
+		if err != nil {
+			return err
+		}
+		for _, v := range violations {
+			r.Report(report.Finding{
+				RuleID:   "values.schema",
+				Severity: report.SeverityError,
+				File:     schemaPath,
+				Message:  fmt.Sprintf("%s: %s", v.Pointer, v.Message),
+			})
+		}
+	}
+	return nil
+}
+
+// lintExtensionsImages checks that every image declared in extension.yaml is
+// actually referenced by the rendered chart, and vice versa. When
+// o.CheckImages is set it additionally resolves each declared image against
+// its registry, reporting images that can't be resolved, carry no tag, or
+// whose manifest list doesn't cover every platform in o.Platforms.
+func lintExtensionsImages(o *options.LintOptions, r report.Reporter, charts chart.Chart, extension string, images []string) error {
 	fmt.Print("\nInfo: lint images\n")
 	if len(images) == 0 {
-		fmt.Printf("WARNING: extension %s has no images\n", extension)
+		r.Report(report.Finding{
+			RuleID:   "images.declared",
+			Severity: report.SeverityWarning,
+			File:     extension,
+			Message:  "extension has no images",
+		})
 		return nil
 	}
 
@@ -151,59 +334,116 @@ func lintExtensionsImages(charts chart.Chart, extension string, images []string)
 		return err
 	}
 
+	var yamlFiles []string
+	for name, content := range files {
+		// only find in yaml files
+		if !strings.HasSuffix(name, ".yaml") && !strings.HasSuffix(name, ".yml") {
+			continue
+		}
+		yamlFiles = append(yamlFiles, content)
+	}
+
+	declared := make(map[string]bool, len(images))
 	for _, image := range images {
-		for name, content := range files {
-			// only find in yaml files
-			if !strings.HasSuffix(name, ".yaml") && !strings.HasSuffix(name, ".yml") {
-				continue
-			}
+		declared[image] = true
+		found := false
+		for _, content := range yamlFiles {
 			if strings.Contains(content, image) {
-				goto found
+				found = true
+				break
+			}
+		}
+		if !found {
+			r.Report(report.Finding{
+				RuleID:      "images.unreferenced",
+				Severity:    report.SeverityError,
+				File:        extension,
+				Message:     fmt.Sprintf("image %s has not found", image),
+				Remediation: "reference the declared image from a template, or remove it from extension.yaml",
+			})
+		}
+	}
+
+	for _, content := range yamlFiles {
+		for _, image := range findImageReferences(content) {
+			if !declared[image] {
+				r.Report(report.Finding{
+					RuleID:      "images.undeclared",
+					Severity:    report.SeverityError,
+					File:        extension,
+					Message:     fmt.Sprintf("image %s is referenced by the chart but not declared in extension.yaml", image),
+					Remediation: "add the image to extension.yaml's Metadata.Images",
+				})
 			}
 		}
-		fmt.Printf("ERROR: image %s has not found\n", image)
-	found:
+	}
+
+	if o == nil || !o.CheckImages {
+		return nil
+	}
+
+	auth := make(map[string]authn.AuthConfig, len(o.RegistryAuth))
+	for host, cred := range o.RegistryAuth {
+		auth[host] = authn.AuthConfig{Username: cred.Username, Password: cred.Password}
+	}
+	client := registry.NewClient(auth)
+	for _, finding := range registry.VerifyAll(client, images, o.Platforms, o.ImageCheckWorkers) {
+		r.Report(report.Finding{
+			RuleID:   "images.registry",
+			Severity: report.SeverityError,
+			File:     extension,
+			Message:  fmt.Sprintf("image %s: %s", finding.Image, finding.Problem),
+		})
 	}
 	return nil
 }
 
-func lintGlobalNodeSelector(charts chart.Chart, extension string) error {
+// findImageReferences extracts the values of every "image:" field in a
+// rendered manifest, so they can be cross-checked against extension.yaml.
+func findImageReferences(content string) []string {
+	var images []string
+	for _, line := range strings.Split(content, "\n") {
+		trimmed := strings.TrimSpace(line)
+		trimmed = strings.TrimSpace(strings.TrimPrefix(trimmed, "-"))
+		if !strings.HasPrefix(trimmed, "image:") {
+			continue
+		}
+		value := strings.TrimSpace(strings.TrimPrefix(trimmed, "image:"))
+		value = strings.Trim(value, `"'`)
+		if value != "" {
+			images = append(images, value)
+		}
+	}
+	return images
+}
+
+func lintGlobalNodeSelector(r report.Reporter, charts chart.Chart, extension string) error {
 	fmt.Print("\nInfo: lint global.nodeSelector\n")
 	key := rand.String(12)
-	files, err := getTemplateFile(&charts, &values.Options{
-		JSONValues: []string{fmt.Sprintf("global.nodeSelector={\"kubernetes.io/os\": \"%s\"}", key)},
-	})
-	if err != nil {
-		return err
-	}
 
-	for name, content := range files {
-		// only find in yaml files
-		if !strings.HasSuffix(name, ".yaml") && !strings.HasSuffix(name, ".yml") {
-			continue
+	for _, sc := range subcharts(&charts, extension) {
+		files, err := getTemplateFile(sc.chart, &values.Options{
+			JSONValues: []string{fmt.Sprintf("global.nodeSelector={\"kubernetes.io/os\": \"%s\"}", key)},
+		})
+		if err != nil {
+			return err
 		}
-		yamlArr := strings.Split(content, "---")
-		for _, y := range yamlArr {
-			yamlMap := make(map[string]any)
-			if err := yaml.Unmarshal([]byte(y), &yamlMap); err != nil {
-				return err
-			}
-			switch yamlMap["kind"] {
-			case "Deployment", "StatefulSet", "ReplicaSet", "Job":
-				if yamlMap["spec"].(map[string]any)["template"].(map[string]any)["spec"].(map[string]any)["nodeSelector"] == nil ||
-					yamlMap["spec"].(map[string]any)["template"].(map[string]any)["spec"].(map[string]any)["nodeSelector"].(map[string]any)["kubernetes.io/os"] != key {
-					fmt.Printf("ERROR: golobal.nodeSelector doesn't work in extension: %s file: %s Resource: {kind %s, name:%s }\n", extension, name, yamlMap["kind"], yamlMap["metadata"].(map[string]any)["name"])
-				}
 
-			case "Pod":
-				if yamlMap["spec"].(map[string]any)["nodeSelector"] == nil ||
-					yamlMap["spec"].(map[string]any)["nodeSelector"].(map[string]any)["kubernetes.io/os"] != key {
-					fmt.Printf("ERROR: golobal.nodeSelector doesn't work in extension: %s file: %s Resource: {kind %s, name:%s }\n", extension, name, yamlMap["kind"], yamlMap["metadata"].(map[string]any)["name"])
+		for name, content := range files {
+			// only find in yaml files
+			if !strings.HasSuffix(name, ".yaml") && !strings.HasSuffix(name, ".yml") {
+				continue
+			}
+			for _, y := range strings.Split(content, "---") {
+				yamlMap := make(map[string]any)
+				if err := yaml.Unmarshal([]byte(y), &yamlMap); err != nil {
+					return err
 				}
-			case "CronJob":
-				if yamlMap["spec"].(map[string]any)["jobTemplate"].(map[string]any)["spec"].(map[string]any)["template"].(map[string]any)["spec"].(map[string]any)["nodeSelector"] == nil ||
-					yamlMap["spec"].(map[string]any)["jobTemplate"].(map[string]any)["spec"].(map[string]any)["template"].(map[string]any)["spec"].(map[string]any)["nodeSelector"].(map[string]any)["kubernetes.io/os"] != key {
-					fmt.Printf("ERROR: golobal.nodeSelector doesn't work in extension: %s file: %s Resource: {kind %s, name:%s }\n", extension, name, yamlMap["kind"], yamlMap["metadata"].(map[string]any)["name"])
+				for _, pod := range ExtractPodSpecs(yamlMap) {
+					nodeSelector, _ := pod.Spec["nodeSelector"].(map[string]any)
+					if nodeSelector["kubernetes.io/os"] != key {
+						r.Report(nodeSelectorFinding(sc.path, name, yamlMap))
+					}
 				}
 			}
 		}
@@ -211,80 +451,45 @@ func lintGlobalNodeSelector(charts chart.Chart, extension string) error {
 	return nil
 }
 
-func lintGlobalImageRegistry(charts chart.Chart, extension string) error {
+func nodeSelectorFinding(extension, file string, yamlMap map[string]any) report.Finding {
+	kind, _ := yamlMap["kind"].(string)
+	name, _ := yamlMap["metadata"].(map[string]any)["name"].(string)
+	return report.Finding{
+		RuleID:      "global.nodeSelector",
+		Severity:    report.SeverityError,
+		File:        file,
+		Kind:        kind,
+		Name:        name,
+		Message:     fmt.Sprintf("global.nodeSelector doesn't work in extension %s", extension),
+		Remediation: "propagate {{ .Values.global.nodeSelector }} into the pod template's nodeSelector",
+	}
+}
+
+func lintGlobalImageRegistry(r report.Reporter, charts chart.Chart, extension string) error {
 	fmt.Print("\nInfo: lint global.imageRegistry\n")
 	key := rand.String(12)
-	files, err := getTemplateFile(&charts, &values.Options{
-		Values: []string{fmt.Sprintf("global.imageRegistry=%s", key)},
-	})
-	if err != nil {
-		return err
-	}
 
-	for name, content := range files {
-		// only find in yaml files
-		if !strings.HasSuffix(name, ".yaml") && !strings.HasSuffix(name, ".yml") {
-			continue
+	for _, sc := range subcharts(&charts, extension) {
+		files, err := getTemplateFile(sc.chart, &values.Options{
+			Values: []string{fmt.Sprintf("global.imageRegistry=%s", key)},
+		})
+		if err != nil {
+			return err
 		}
-		yamlArr := strings.Split(content, "---")
-		for _, y := range yamlArr {
-			yamlMap := make(map[string]any)
-			if err := yaml.Unmarshal([]byte(y), &yamlMap); err != nil {
-				return err
-			}
-			switch yamlMap["kind"] {
-			case "Deployment", "StatefulSet", "DaemonSet", "ReplicaSet", "Job":
-				// init container
-				if initContainer, ok := yamlMap["spec"].(map[string]any)["template"].(map[string]any)["spec"].(map[string]any)["initContainers"].([]any); ok {
-					for _, c := range initContainer {
-						if !strings.Contains(c.(map[string]any)["image"].(string), key) {
-							fmt.Printf("ERROR: golobal.imageRegistry doesn't work in init-cotainer %s of extension: %s file: %s Resource: {kind %s, name:%s }\n", c.(map[string]any)["name"], extension, name, yamlMap["kind"], yamlMap["metadata"].(map[string]any)["name"])
-						}
-					}
-				}
-				// container
-				if container, ok := yamlMap["spec"].(map[string]any)["template"].(map[string]any)["spec"].(map[string]any)["containers"].([]any); ok {
-					for _, c := range container {
-						if !strings.Contains(c.(map[string]any)["image"].(string), key) {
-							fmt.Printf("ERROR: golobal.imageRegistry doesn't work in cotainer %s of extension: %s file: %s Resource: {kind %s, name:%s }\n", c.(map[string]any)["name"], extension, name, yamlMap["kind"], yamlMap["metadata"].(map[string]any)["name"])
-						}
-					}
-				}
-
-			case "Pod":
-				// init container
-				if initContainer, ok := yamlMap["spec"].(map[string]any)["initContainers"].([]any); ok {
-					for _, c := range initContainer {
-						if !strings.Contains(c.(map[string]any)["image"].(string), key) {
-							fmt.Printf("ERROR: golobal.imageRegistry doesn't work in init-cotainer %s of extension: %s file: %s Resource: {kind %s, name:%s }\n", c.(map[string]any)["name"], extension, name, yamlMap["kind"], yamlMap["metadata"].(map[string]any)["name"])
-						}
-					}
-				}
-				// container
-				if container, ok := yamlMap["spec"].(map[string]any)["containers"].([]any); ok {
-					for _, c := range container {
-						if !strings.Contains(c.(map[string]any)["image"].(string), key) {
-							fmt.Printf("ERROR: golobal.imageRegistry doesn't work in cotainer %s of extension: %s file: %s Resource: {kind %s, name:%s }\n", c.(map[string]any)["name"], extension, name, yamlMap["kind"], yamlMap["metadata"].(map[string]any)["name"])
-						}
-					}
-				}
 
-			case "CronJob":
-				// init container
-				if initContainer, ok := yamlMap["spec"].(map[string]any)["jobTemplate"].(map[string]any)["spec"].(map[string]any)["template"].(map[string]any)["spec"].(map[string]any)["initContainers"].([]any); ok {
-					for _, c := range initContainer {
-						if !strings.Contains(c.(map[string]any)["image"].(string), key) {
-							fmt.Printf("ERROR: golobal.imageRegistry doesn't work in init-cotainer %s of extension: %s file: %s Resource: {kind %s, name:%s }\n", c.(map[string]any)["name"], extension, name, yamlMap["kind"], yamlMap["metadata"].(map[string]any)["name"])
-						}
-					}
+		for name, content := range files {
+			// only find in yaml files
+			if !strings.HasSuffix(name, ".yaml") && !strings.HasSuffix(name, ".yml") {
+				continue
+			}
+			for _, y := range strings.Split(content, "---") {
+				yamlMap := make(map[string]any)
+				if err := yaml.Unmarshal([]byte(y), &yamlMap); err != nil {
+					return err
 				}
-				// container
-				if container, ok := yamlMap["spec"].(map[string]any)["jobTemplate"].(map[string]any)["spec"].(map[string]any)["template"].(map[string]any)["spec"].(map[string]any)["containers"].([]any); ok {
-					for _, c := range container {
-						if !strings.Contains(c.(map[string]any)["image"].(string), key) {
-							fmt.Printf("ERROR: golobal.imageRegistry doesn't work in cotainer %s of extension: %s file: %s Resource: {kind %s, name:%s }\n", c.(map[string]any)["name"], extension, name, yamlMap["kind"], yamlMap["metadata"].(map[string]any)["name"])
-						}
-					}
+				for _, pod := range ExtractPodSpecs(yamlMap) {
+					checkImageRegistry(r, pod.Spec, "initContainers", key, sc.path, name, yamlMap)
+					checkImageRegistry(r, pod.Spec, "containers", key, sc.path, name, yamlMap)
 				}
 			}
 		}
@@ -292,6 +497,47 @@ func lintGlobalImageRegistry(charts chart.Chart, extension string) error {
 	return nil
 }
 
+// chartPath pairs a chart with the slash-separated path lint findings
+// should be attributed to, rooted at the extension directory being linted.
+type chartPath struct {
+	chart *chart.Chart
+	path  string
+}
+
+// subcharts returns c and every dependency beneath it, recursively, so the
+// KubeSphere builtin lints can cover workloads defined inside subcharts and
+// attribute their findings back to the subchart they came from.
+func subcharts(c *chart.Chart, path string) []chartPath {
+	result := []chartPath{{chart: c, path: path}}
+	for _, dep := range c.Dependencies() {
+		result = append(result, subcharts(dep, filepath.Join(path, "charts", dep.Name()))...)
+	}
+	return result
+}
+
+func checkImageRegistry(r report.Reporter, podSpec map[string]any, field, key, extension, file string, yamlMap map[string]any) {
+	containers, ok := podSpec[field].([]any)
+	if !ok {
+		return
+	}
+	kind, _ := yamlMap["kind"].(string)
+	resourceName, _ := yamlMap["metadata"].(map[string]any)["name"].(string)
+	for _, c := range containers {
+		container := c.(map[string]any)
+		if !strings.Contains(container["image"].(string), key) {
+			r.Report(report.Finding{
+				RuleID:      "global.imageRegistry",
+				Severity:    report.SeverityError,
+				File:        file,
+				Kind:        kind,
+				Name:        resourceName,
+				Message:     fmt.Sprintf("global.imageRegistry doesn't work in container %s of extension %s", container["name"], extension),
+				Remediation: "prefix the image with {{ .Values.global.imageRegistry }}",
+			})
+		}
+	}
+}
+
 func getTemplateFile(chartRequested *chart.Chart, valueOpts *values.Options) (map[string]string, error) {
 	p := getter.All(cli.New())
 	vals, err := valueOpts.MergeValues(p)
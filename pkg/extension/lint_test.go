@@ -0,0 +1,31 @@
+package extension
+
+import (
+	"testing"
+
+	"helm.sh/helm/v3/pkg/chart"
+)
+
+func TestSubcharts(t *testing.T) {
+	leaf := &chart.Chart{Metadata: &chart.Metadata{Name: "leaf"}}
+	mid := &chart.Chart{Metadata: &chart.Metadata{Name: "mid"}}
+	mid.AddDependency(leaf)
+	top := &chart.Chart{Metadata: &chart.Metadata{Name: "top"}}
+	top.AddDependency(mid)
+
+	got := subcharts(top, "ext")
+
+	want := map[string]bool{
+		"ext":                        true,
+		"ext/charts/mid":             true,
+		"ext/charts/mid/charts/leaf": true,
+	}
+	if len(got) != len(want) {
+		t.Fatalf("subcharts() = %d entries, want %d", len(got), len(want))
+	}
+	for _, sc := range got {
+		if !want[sc.path] {
+			t.Errorf("unexpected path %q", sc.path)
+		}
+	}
+}
@@ -0,0 +1,99 @@
+package extension
+
+// PodSpec is a pod template extracted from an unstructured Kubernetes
+// object, together with the JSONPath it was found at so lint findings can
+// point back at the field that needs fixing.
+type PodSpec struct {
+	// NodeSelectorPath is the JSONPath of the nodeSelector field relative
+	// to the object root, e.g. "spec.template.spec.nodeSelector".
+	NodeSelectorPath string
+	// ContainersPath is the JSONPath of the containers field relative to
+	// the object root, e.g. "spec.template.spec.containers".
+	ContainersPath string
+	Spec           map[string]any
+}
+
+// PodSpecExtractor returns the PodSpecs owned by an unstructured object, or
+// nil if the extractor doesn't recognize obj's shape. Extractors are looked
+// up by the object's "kind", so a single extractor can be registered for
+// every kind that shares the same pod template shape.
+type PodSpecExtractor func(obj map[string]any) []PodSpec
+
+var podSpecExtractors = map[string]PodSpecExtractor{}
+
+// RegisterPodSpecExtractor associates kind with an extractor, overriding any
+// extractor previously registered for that kind. Call it from an init()
+// func to teach the KubeSphere builtin lints about workload-owning kinds
+// beyond the defaults registered below, e.g. argoproj.io/Rollout or
+// kubevirt.io/VirtualMachine.
+func RegisterPodSpecExtractor(kind string, extractor PodSpecExtractor) {
+	podSpecExtractors[kind] = extractor
+}
+
+// ExtractPodSpecs returns the PodSpecs owned by obj using the extractor
+// registered for its "kind", or nil if no extractor is registered or obj
+// doesn't have the shape the extractor expects.
+func ExtractPodSpecs(obj map[string]any) []PodSpec {
+	kind, _ := obj["kind"].(string)
+	extractor, ok := podSpecExtractors[kind]
+	if !ok {
+		return nil
+	}
+	return extractor(obj)
+}
+
+func init() {
+	workloadExtractor := func(obj map[string]any) []PodSpec {
+		podSpec, ok := nestedMap(obj, "spec", "template", "spec")
+		if !ok {
+			return nil
+		}
+		return []PodSpec{{
+			NodeSelectorPath: "spec.template.spec.nodeSelector",
+			ContainersPath:   "spec.template.spec.containers",
+			Spec:             podSpec,
+		}}
+	}
+	for _, kind := range []string{"Deployment", "StatefulSet", "DaemonSet", "ReplicaSet", "Job"} {
+		RegisterPodSpecExtractor(kind, workloadExtractor)
+	}
+
+	RegisterPodSpecExtractor("Pod", func(obj map[string]any) []PodSpec {
+		podSpec, ok := nestedMap(obj, "spec")
+		if !ok {
+			return nil
+		}
+		return []PodSpec{{
+			NodeSelectorPath: "spec.nodeSelector",
+			ContainersPath:   "spec.containers",
+			Spec:             podSpec,
+		}}
+	})
+
+	RegisterPodSpecExtractor("CronJob", func(obj map[string]any) []PodSpec {
+		podSpec, ok := nestedMap(obj, "spec", "jobTemplate", "spec", "template", "spec")
+		if !ok {
+			return nil
+		}
+		return []PodSpec{{
+			NodeSelectorPath: "spec.jobTemplate.spec.template.spec.nodeSelector",
+			ContainersPath:   "spec.jobTemplate.spec.template.spec.containers",
+			Spec:             podSpec,
+		}}
+	})
+}
+
+// nestedMap walks obj through fields, returning the map[string]any found at
+// the end of the path, or false if any step along the way is missing or not
+// a map.
+func nestedMap(obj map[string]any, fields ...string) (map[string]any, bool) {
+	cur := obj
+	for _, field := range fields {
+		next, ok := cur[field].(map[string]any)
+		if !ok {
+			return nil, false
+		}
+		cur = next
+	}
+	return cur, true
+}
@@ -0,0 +1,149 @@
+package extension
+
+import "testing"
+
+func TestExtractPodSpecs(t *testing.T) {
+	cases := []struct {
+		name string
+		obj  map[string]any
+		want int
+	}{
+		{
+			name: "Deployment",
+			obj: map[string]any{
+				"kind": "Deployment",
+				"spec": map[string]any{
+					"template": map[string]any{
+						"spec": map[string]any{
+							"containers": []any{map[string]any{"name": "app"}},
+						},
+					},
+				},
+			},
+			want: 1,
+		},
+		{
+			name: "Pod",
+			obj: map[string]any{
+				"kind": "Pod",
+				"spec": map[string]any{
+					"containers": []any{map[string]any{"name": "app"}},
+				},
+			},
+			want: 1,
+		},
+		{
+			name: "CronJob",
+			obj: map[string]any{
+				"kind": "CronJob",
+				"spec": map[string]any{
+					"jobTemplate": map[string]any{
+						"spec": map[string]any{
+							"template": map[string]any{
+								"spec": map[string]any{
+									"containers": []any{map[string]any{"name": "app"}},
+								},
+							},
+						},
+					},
+				},
+			},
+			want: 1,
+		},
+		{
+			name: "unknown kind",
+			obj:  map[string]any{"kind": "ConfigMap"},
+			want: 0,
+		},
+		{
+			name: "Deployment missing pod template",
+			obj:  map[string]any{"kind": "Deployment", "spec": map[string]any{}},
+			want: 0,
+		},
+	}
+
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			got := ExtractPodSpecs(c.obj)
+			if len(got) != c.want {
+				t.Fatalf("ExtractPodSpecs() = %d pod specs, want %d", len(got), c.want)
+			}
+		})
+	}
+}
+
+func TestExtractPodSpecsCronJobPaths(t *testing.T) {
+	obj := map[string]any{
+		"kind": "CronJob",
+		"spec": map[string]any{
+			"jobTemplate": map[string]any{
+				"spec": map[string]any{
+					"template": map[string]any{
+						"spec": map[string]any{
+							"containers": []any{},
+						},
+					},
+				},
+			},
+		},
+	}
+
+	pods := ExtractPodSpecs(obj)
+	if len(pods) != 1 {
+		t.Fatalf("ExtractPodSpecs() = %d pod specs, want 1", len(pods))
+	}
+	if want := "spec.jobTemplate.spec.template.spec.nodeSelector"; pods[0].NodeSelectorPath != want {
+		t.Errorf("NodeSelectorPath = %q, want %q", pods[0].NodeSelectorPath, want)
+	}
+	if want := "spec.jobTemplate.spec.template.spec.containers"; pods[0].ContainersPath != want {
+		t.Errorf("ContainersPath = %q, want %q", pods[0].ContainersPath, want)
+	}
+}
+
+func TestRegisterPodSpecExtractor(t *testing.T) {
+	RegisterPodSpecExtractor("Rollout", func(obj map[string]any) []PodSpec {
+		podSpec, ok := nestedMap(obj, "spec", "template", "spec")
+		if !ok {
+			return nil
+		}
+		return []PodSpec{{Spec: podSpec}}
+	})
+	defer delete(podSpecExtractors, "Rollout")
+
+	obj := map[string]any{
+		"kind": "Rollout",
+		"spec": map[string]any{
+			"template": map[string]any{
+				"spec": map[string]any{},
+			},
+		},
+	}
+	if got := ExtractPodSpecs(obj); len(got) != 1 {
+		t.Fatalf("ExtractPodSpecs() = %d pod specs, want 1", len(got))
+	}
+}
+
+func TestNestedMap(t *testing.T) {
+	obj := map[string]any{
+		"spec": map[string]any{
+			"template": map[string]any{
+				"spec": map[string]any{"containers": []any{}},
+			},
+		},
+	}
+
+	got, ok := nestedMap(obj, "spec", "template", "spec")
+	if !ok {
+		t.Fatal("nestedMap() = false, want true")
+	}
+	if _, ok := got["containers"]; !ok {
+		t.Errorf("nestedMap() = %v, missing containers", got)
+	}
+
+	if _, ok := nestedMap(obj, "spec", "missing"); ok {
+		t.Error("nestedMap() = true for a missing field, want false")
+	}
+	if _, ok := nestedMap(obj, "spec", "template", "spec", "containers"); ok {
+		t.Error("nestedMap() = true for a non-map leaf, want false")
+	}
+}
@@ -0,0 +1,195 @@
+// Package registry resolves the container images declared by a KubeSphere
+// extension against their origin registries, so that `ksbuilder extension lint`
+// can catch typos, missing tags and manifest lists that don't cover the
+// platforms a KubeSphere cluster requires.
+package registry
+
+import (
+	"fmt"
+	"strings"
+	"sync"
+
+	"github.com/google/go-containerregistry/pkg/authn"
+	"github.com/google/go-containerregistry/pkg/name"
+	v1 "github.com/google/go-containerregistry/pkg/v1"
+	"github.com/google/go-containerregistry/pkg/v1/remote"
+)
+
+// Finding describes a single problem found while verifying an image
+// against its registry.
+type Finding struct {
+	Image   string
+	Problem string
+}
+
+// Client resolves image references against their registries, caching
+// manifest list lookups by image and digest for the Client's lifetime so an
+// image verified more than once is not re-parsed. The cache is in memory
+// only and does not outlive the Client.
+type Client struct {
+	keychain authn.Keychain
+
+	mu    sync.Mutex
+	cache map[string]*v1.IndexManifest
+}
+
+// NewClient returns a Client that authenticates using the local
+// ~/.docker/config.json (and any credential helpers it configures),
+// overridden per registry host by auth.
+func NewClient(auth map[string]authn.AuthConfig) *Client {
+	return &Client{
+		keychain: overrideKeychain{overrides: auth, fallback: authn.DefaultKeychain},
+		cache:    map[string]*v1.IndexManifest{},
+	}
+}
+
+// overrideKeychain resolves credentials for a registry from a per-host
+// override map, falling back to another keychain (typically
+// authn.DefaultKeychain, i.e. ~/.docker/config.json) when the registry has
+// no override configured.
+type overrideKeychain struct {
+	overrides map[string]authn.AuthConfig
+	fallback  authn.Keychain
+}
+
+func (k overrideKeychain) Resolve(target authn.Resource) (authn.Authenticator, error) {
+	if cfg, ok := k.overrides[target.RegistryStr()]; ok {
+		return authn.FromConfig(cfg), nil
+	}
+	return k.fallback.Resolve(target)
+}
+
+// Verify resolves image against its registry and reports when it has no
+// tag, cannot be resolved, or, when platforms is non-empty, when its
+// manifest list doesn't cover one of the requested platforms.
+func (c *Client) Verify(image string, platforms []string) []Finding {
+	if !hasTagOrDigest(image) {
+		return []Finding{{Image: image, Problem: "missing tag"}}
+	}
+
+	ref, err := name.ParseReference(image)
+	if err != nil {
+		return []Finding{{Image: image, Problem: fmt.Sprintf("not resolvable: %v", err)}}
+	}
+
+	desc, err := remote.Get(ref, remote.WithAuthFromKeychain(c.keychain))
+	if err != nil {
+		return []Finding{{Image: image, Problem: fmt.Sprintf("not resolvable: %v", err)}}
+	}
+	if len(platforms) == 0 {
+		return nil
+	}
+
+	idx := c.indexManifest(image, desc)
+	if idx == nil {
+		// single-arch image: nothing further to check against a manifest list.
+		return nil
+	}
+
+	var findings []Finding
+	for _, p := range missingPlatforms(idx, platforms) {
+		findings = append(findings, Finding{Image: image, Problem: fmt.Sprintf("manifest list does not cover platform %s", p)})
+	}
+	return findings
+}
+
+// hasTagOrDigest reports whether image carries an explicit tag or digest.
+// name.ParseReference silently defaults an untagged reference to ":latest",
+// so the check has to happen against the original string, not the parsed
+// reference.
+func hasTagOrDigest(image string) bool {
+	if strings.Contains(image, "@") {
+		return true
+	}
+	// A ':' only marks a tag when it comes after the last '/' — before
+	// that it may just separate a registry host from its port, as in
+	// "registry.example.com:5000/app".
+	return strings.LastIndex(image, ":") > strings.LastIndex(image, "/")
+}
+
+// missingPlatforms returns the platforms, from platforms, that idx's
+// manifest list doesn't cover.
+func missingPlatforms(idx *v1.IndexManifest, platforms []string) []string {
+	have := make(map[string]bool, len(idx.Manifests))
+	for _, m := range idx.Manifests {
+		if m.Platform == nil {
+			continue
+		}
+		have[m.Platform.OS+"/"+m.Platform.Architecture] = true
+	}
+
+	var missing []string
+	for _, p := range platforms {
+		if !have[p] {
+			missing = append(missing, p)
+		}
+	}
+	return missing
+}
+
+func (c *Client) indexManifest(image string, desc *remote.Descriptor) *v1.IndexManifest {
+	key := image + "@" + desc.Digest.String()
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if m, ok := c.cache[key]; ok {
+		return m
+	}
+
+	if !desc.MediaType.IsIndex() {
+		c.cache[key] = nil
+		return nil
+	}
+	idx, err := desc.ImageIndex()
+	if err != nil {
+		c.cache[key] = nil
+		return nil
+	}
+	manifest, err := idx.IndexManifest()
+	if err != nil {
+		c.cache[key] = nil
+		return nil
+	}
+	c.cache[key] = manifest
+	return manifest
+}
+
+// VerifyAll runs Verify over images concurrently using a bounded pool of
+// workers and returns every finding, preserving the input order of images.
+func VerifyAll(c *Client, images []string, platforms []string, workers int) []Finding {
+	if workers <= 0 {
+		workers = 4
+	}
+	if workers > len(images) {
+		workers = len(images)
+	}
+
+	type job struct {
+		index int
+		image string
+	}
+	jobs := make(chan job)
+	results := make([][]Finding, len(images))
+
+	var wg sync.WaitGroup
+	for i := 0; i < workers; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for j := range jobs {
+				results[j.index] = c.Verify(j.image, platforms)
+			}
+		}()
+	}
+	for i, image := range images {
+		jobs <- job{index: i, image: image}
+	}
+	close(jobs)
+	wg.Wait()
+
+	var findings []Finding
+	for _, r := range results {
+		findings = append(findings, r...)
+	}
+	return findings
+}
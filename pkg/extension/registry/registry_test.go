@@ -0,0 +1,52 @@
+package registry
+
+import (
+	"testing"
+
+	v1 "github.com/google/go-containerregistry/pkg/v1"
+)
+
+func TestHasTagOrDigest(t *testing.T) {
+	cases := map[string]bool{
+		"nginx":                                 false,
+		"nginx:1.25":                             true,
+		"nginx@sha256:" + strRepeat("a", 64):     true,
+		"registry.example.com:5000/app":          false,
+		"registry.example.com:5000/app:v1":       true,
+		"registry.example.com:5000/team/app:v1":  true,
+		"registry.example.com/app@sha256:abcdef": true,
+	}
+	for image, want := range cases {
+		if got := hasTagOrDigest(image); got != want {
+			t.Errorf("hasTagOrDigest(%q) = %v, want %v", image, got, want)
+		}
+	}
+}
+
+func strRepeat(s string, n int) string {
+	out := make([]byte, 0, n*len(s))
+	for i := 0; i < n; i++ {
+		out = append(out, s...)
+	}
+	return string(out)
+}
+
+func TestMissingPlatforms(t *testing.T) {
+	idx := &v1.IndexManifest{
+		Manifests: []v1.Descriptor{
+			{Platform: &v1.Platform{OS: "linux", Architecture: "amd64"}},
+			{Platform: &v1.Platform{OS: "linux", Architecture: "arm64"}},
+			{Platform: nil},
+		},
+	}
+
+	got := missingPlatforms(idx, []string{"linux/amd64", "linux/arm64", "linux/ppc64le"})
+	want := []string{"linux/ppc64le"}
+	if len(got) != len(want) || got[0] != want[0] {
+		t.Errorf("missingPlatforms() = %v, want %v", got, want)
+	}
+
+	if got := missingPlatforms(idx, []string{"linux/amd64"}); len(got) != 0 {
+		t.Errorf("missingPlatforms() = %v, want none missing", got)
+	}
+}
@@ -0,0 +1,276 @@
+// Package report collects findings produced by the various `ksbuilder
+// extension lint` phases into a single structured report that can be
+// rendered as plain text, JSON, or SARIF 2.1.0 for CI ingestion.
+package report
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"strings"
+
+	"helm.sh/helm/v3/pkg/lint/support"
+)
+
+// Severity orders findings from least to most severe so a caller can
+// compare a finding against a floor to decide the process exit code.
+type Severity int
+
+const (
+	SeverityInfo Severity = iota
+	SeverityWarning
+	SeverityError
+)
+
+// ParseSeverity parses the --severity flag value, defaulting to warning.
+func ParseSeverity(s string) (Severity, error) {
+	switch strings.ToLower(s) {
+	case "", "warning":
+		return SeverityWarning, nil
+	case "info":
+		return SeverityInfo, nil
+	case "error":
+		return SeverityError, nil
+	default:
+		return 0, fmt.Errorf("unknown severity %q, must be one of info, warning, error", s)
+	}
+}
+
+func (s Severity) String() string {
+	switch s {
+	case SeverityError:
+		return "error"
+	case SeverityWarning:
+		return "warning"
+	default:
+		return "info"
+	}
+}
+
+// Finding is a single lint result, normalized so that both the helm lint
+// phase and the KubeSphere builtin lints can feed the same report.
+type Finding struct {
+	RuleID      string
+	Severity    Severity
+	File        string
+	Kind        string
+	Name        string
+	Message     string
+	Remediation string
+}
+
+// Reporter collects Findings as lint phases run and renders them in the
+// format requested via `--format`.
+type Reporter interface {
+	// Report records a single finding.
+	Report(f Finding)
+	// Findings returns every finding recorded so far.
+	Findings() []Finding
+	// HasSeverity reports whether any recorded finding is at or above floor.
+	HasSeverity(floor Severity) bool
+	// Flush renders every recorded finding to w in the Reporter's format.
+	Flush(w io.Writer) error
+}
+
+type reporter struct {
+	format   string
+	findings []Finding
+}
+
+// New returns a Reporter rendering as one of "text" (the default), "json"
+// or "sarif".
+func New(format string) (Reporter, error) {
+	switch format {
+	case "", "text", "json", "sarif":
+		if format == "" {
+			format = "text"
+		}
+		return &reporter{format: format}, nil
+	default:
+		return nil, fmt.Errorf("unknown report format %q, must be one of text, json, sarif", format)
+	}
+}
+
+func (r *reporter) Report(f Finding) {
+	r.findings = append(r.findings, f)
+}
+
+func (r *reporter) Findings() []Finding {
+	return r.findings
+}
+
+func (r *reporter) HasSeverity(floor Severity) bool {
+	for _, f := range r.findings {
+		if f.Severity >= floor {
+			return true
+		}
+	}
+	return false
+}
+
+func (r *reporter) Flush(w io.Writer) error {
+	switch r.format {
+	case "json":
+		return r.flushJSON(w)
+	case "sarif":
+		return r.flushSARIF(w)
+	default:
+		return r.flushText(w)
+	}
+}
+
+func (r *reporter) flushText(w io.Writer) error {
+	for _, f := range r.findings {
+		loc := f.File
+		if f.Kind != "" || f.Name != "" {
+			loc = strings.TrimSpace(fmt.Sprintf("%s [%s %s]", loc, f.Kind, f.Name))
+		}
+		if loc != "" {
+			loc = " " + loc
+		}
+		if _, err := fmt.Fprintf(w, "%s: %s%s: %s\n", strings.ToUpper(f.Severity.String()), f.RuleID, loc, f.Message); err != nil {
+			return err
+		}
+		if f.Remediation != "" {
+			if _, err := fmt.Fprintf(w, "  hint: %s\n", f.Remediation); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+type jsonFinding struct {
+	RuleID      string `json:"ruleId"`
+	Severity    string `json:"severity"`
+	File        string `json:"file,omitempty"`
+	Kind        string `json:"kind,omitempty"`
+	Name        string `json:"name,omitempty"`
+	Message     string `json:"message"`
+	Remediation string `json:"remediation,omitempty"`
+}
+
+func (r *reporter) flushJSON(w io.Writer) error {
+	out := make([]jsonFinding, len(r.findings))
+	for i, f := range r.findings {
+		out[i] = jsonFinding{
+			RuleID:      f.RuleID,
+			Severity:    f.Severity.String(),
+			File:        f.File,
+			Kind:        f.Kind,
+			Name:        f.Name,
+			Message:     f.Message,
+			Remediation: f.Remediation,
+		}
+	}
+	enc := json.NewEncoder(w)
+	enc.SetIndent("", "  ")
+	return enc.Encode(out)
+}
+
+// The following types are the minimal subset of the SARIF 2.1.0 schema
+// (https://docs.oasis-open.org/sarif/sarif/v2.1.0) needed to report lint
+// findings to GitHub code scanning or SonarQube.
+type sarifLog struct {
+	Schema  string     `json:"$schema"`
+	Version string     `json:"version"`
+	Runs    []sarifRun `json:"runs"`
+}
+
+type sarifRun struct {
+	Tool    sarifTool     `json:"tool"`
+	Results []sarifResult `json:"results"`
+}
+
+type sarifTool struct {
+	Driver sarifDriver `json:"driver"`
+}
+
+type sarifDriver struct {
+	Name  string      `json:"name"`
+	Rules []sarifRule `json:"rules"`
+}
+
+type sarifRule struct {
+	ID string `json:"id"`
+}
+
+type sarifResult struct {
+	RuleID    string          `json:"ruleId"`
+	Level     string          `json:"level"`
+	Message   sarifMessage    `json:"message"`
+	Locations []sarifLocation `json:"locations,omitempty"`
+}
+
+type sarifMessage struct {
+	Text string `json:"text"`
+}
+
+type sarifLocation struct {
+	PhysicalLocation sarifPhysicalLocation `json:"physicalLocation"`
+}
+
+type sarifPhysicalLocation struct {
+	ArtifactLocation sarifArtifactLocation `json:"artifactLocation"`
+}
+
+type sarifArtifactLocation struct {
+	URI string `json:"uri"`
+}
+
+func (r *reporter) flushSARIF(w io.Writer) error {
+	seen := map[string]bool{}
+	var rules []sarifRule
+	results := make([]sarifResult, 0, len(r.findings))
+	for _, f := range r.findings {
+		if !seen[f.RuleID] {
+			seen[f.RuleID] = true
+			rules = append(rules, sarifRule{ID: f.RuleID})
+		}
+
+		level := "note"
+		switch f.Severity {
+		case SeverityError:
+			level = "error"
+		case SeverityWarning:
+			level = "warning"
+		}
+
+		result := sarifResult{RuleID: f.RuleID, Level: level, Message: sarifMessage{Text: f.Message}}
+		if f.File != "" {
+			result.Locations = []sarifLocation{{
+				PhysicalLocation: sarifPhysicalLocation{
+					ArtifactLocation: sarifArtifactLocation{URI: f.File},
+				},
+			}}
+		}
+		results = append(results, result)
+	}
+
+	log := sarifLog{
+		Schema:  "https://raw.githubusercontent.com/oasis-tcs/sarif-spec/master/Schemata/sarif-schema-2.1.0.json",
+		Version: "2.1.0",
+		Runs: []sarifRun{{
+			Tool:    sarifTool{Driver: sarifDriver{Name: "ksbuilder", Rules: rules}},
+			Results: results,
+		}},
+	}
+
+	enc := json.NewEncoder(w)
+	enc.SetIndent("", "  ")
+	return enc.Encode(log)
+}
+
+// FromHelmSeverity maps a helm lint support.Severity to a Severity,
+// comparing against the named support.InfoSev/WarningSev/ErrorSev
+// constants rather than assuming their underlying iota values.
+func FromHelmSeverity(sev support.Severity) Severity {
+	switch sev {
+	case support.ErrorSev:
+		return SeverityError
+	case support.WarningSev:
+		return SeverityWarning
+	default:
+		return SeverityInfo
+	}
+}
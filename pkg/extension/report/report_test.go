@@ -0,0 +1,109 @@
+package report
+
+import (
+	"bytes"
+	"encoding/json"
+	"strings"
+	"testing"
+
+	"helm.sh/helm/v3/pkg/lint/support"
+)
+
+func TestFlushJSON(t *testing.T) {
+	r, err := New("json")
+	if err != nil {
+		t.Fatal(err)
+	}
+	r.Report(Finding{RuleID: "images.unreferenced", Severity: SeverityError, File: "chart", Message: "boom"})
+
+	var buf bytes.Buffer
+	if err := r.Flush(&buf); err != nil {
+		t.Fatal(err)
+	}
+
+	var out []jsonFinding
+	if err := json.Unmarshal(buf.Bytes(), &out); err != nil {
+		t.Fatalf("output is not valid JSON: %v", err)
+	}
+	if len(out) != 1 || out[0].RuleID != "images.unreferenced" || out[0].Severity != "error" {
+		t.Errorf("unexpected findings: %+v", out)
+	}
+}
+
+func TestFlushSARIF(t *testing.T) {
+	r, err := New("sarif")
+	if err != nil {
+		t.Fatal(err)
+	}
+	r.Report(Finding{RuleID: "global.nodeSelector", Severity: SeverityWarning, File: "templates/deploy.yaml", Message: "nope"})
+
+	var buf bytes.Buffer
+	if err := r.Flush(&buf); err != nil {
+		t.Fatal(err)
+	}
+
+	var out sarifLog
+	if err := json.Unmarshal(buf.Bytes(), &out); err != nil {
+		t.Fatalf("output is not valid SARIF JSON: %v", err)
+	}
+	if out.Version != "2.1.0" {
+		t.Errorf("Version = %q, want 2.1.0", out.Version)
+	}
+	if len(out.Runs) != 1 || len(out.Runs[0].Results) != 1 {
+		t.Fatalf("unexpected runs: %+v", out.Runs)
+	}
+	result := out.Runs[0].Results[0]
+	if result.RuleID != "global.nodeSelector" || result.Level != "warning" {
+		t.Errorf("unexpected result: %+v", result)
+	}
+	if result.Locations[0].PhysicalLocation.ArtifactLocation.URI != "templates/deploy.yaml" {
+		t.Errorf("unexpected location: %+v", result.Locations)
+	}
+}
+
+func TestFlushTextIncludesRemediation(t *testing.T) {
+	r, err := New("text")
+	if err != nil {
+		t.Fatal(err)
+	}
+	r.Report(Finding{RuleID: "additions.readme", Severity: SeverityWarning, Message: "missing", Remediation: "add one"})
+
+	var buf bytes.Buffer
+	if err := r.Flush(&buf); err != nil {
+		t.Fatal(err)
+	}
+	if !strings.Contains(buf.String(), "hint: add one") {
+		t.Errorf("text output missing remediation hint: %q", buf.String())
+	}
+}
+
+func TestHasSeverity(t *testing.T) {
+	r, err := New("text")
+	if err != nil {
+		t.Fatal(err)
+	}
+	r.Report(Finding{Severity: SeverityWarning})
+
+	if r.HasSeverity(SeverityError) {
+		t.Errorf("HasSeverity(SeverityError) = true, want false")
+	}
+	if !r.HasSeverity(SeverityWarning) {
+		t.Errorf("HasSeverity(SeverityWarning) = false, want true")
+	}
+}
+
+func TestFromHelmSeverity(t *testing.T) {
+	cases := []struct {
+		in   support.Severity
+		want Severity
+	}{
+		{support.ErrorSev, SeverityError},
+		{support.WarningSev, SeverityWarning},
+		{support.InfoSev, SeverityInfo},
+	}
+	for _, c := range cases {
+		if got := FromHelmSeverity(c.in); got != c.want {
+			t.Errorf("FromHelmSeverity(%v) = %v, want %v", c.in, got, c.want)
+		}
+	}
+}
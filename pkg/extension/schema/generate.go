@@ -0,0 +1,124 @@
+package schema
+
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+)
+
+// Generate infers a starter draft-07 values.schema.json from a chart's
+// default values.yaml. Types are inferred from the YAML values themselves;
+// extension authors can enrich a field further with leading comments:
+//
+//	# the registry images are pulled from
+//	# +ksbuilder:enum=docker.io,registry.cn-beijing.aliyuncs.com
+//	# +ksbuilder:required
+//	imageRegistry: docker.io
+func Generate(valuesYAML []byte) ([]byte, error) {
+	var doc yaml.Node
+	if err := yaml.Unmarshal(valuesYAML, &doc); err != nil {
+		return nil, fmt.Errorf("parse values.yaml: %w", err)
+	}
+
+	root := map[string]any{
+		"$schema": "http://json-schema.org/draft-07/schema#",
+		"type":    "object",
+	}
+	if len(doc.Content) > 0 && doc.Content[0].Kind == yaml.MappingNode {
+		for k, v := range mappingSchema(doc.Content[0]) {
+			root[k] = v
+		}
+	}
+
+	out, err := json.MarshalIndent(root, "", "  ")
+	if err != nil {
+		return nil, err
+	}
+	return append(out, '\n'), nil
+}
+
+func valueSchema(node *yaml.Node) map[string]any {
+	switch node.Kind {
+	case yaml.MappingNode:
+		return mappingSchema(node)
+	case yaml.SequenceNode:
+		items := map[string]any{}
+		if len(node.Content) > 0 {
+			items = valueSchema(node.Content[0])
+		}
+		return map[string]any{"type": "array", "items": items}
+	default:
+		return map[string]any{"type": scalarType(node)}
+	}
+}
+
+func mappingSchema(node *yaml.Node) map[string]any {
+	properties := map[string]any{}
+	var required []string
+
+	for i := 0; i+1 < len(node.Content); i += 2 {
+		keyNode, valNode := node.Content[i], node.Content[i+1]
+
+		prop := valueSchema(valNode)
+		desc, enum, isRequired := parseComment(keyNode.HeadComment)
+		if desc != "" {
+			prop["description"] = desc
+		}
+		if len(enum) > 0 {
+			prop["enum"] = enum
+		}
+		if isRequired {
+			required = append(required, keyNode.Value)
+		}
+		properties[keyNode.Value] = prop
+	}
+
+	schema := map[string]any{
+		"type":       "object",
+		"properties": properties,
+	}
+	if len(required) > 0 {
+		schema["required"] = required
+	}
+	return schema
+}
+
+func scalarType(node *yaml.Node) string {
+	switch node.Tag {
+	case "!!bool":
+		return "boolean"
+	case "!!int":
+		return "integer"
+	case "!!float":
+		return "number"
+	case "!!null":
+		return "null"
+	default:
+		return "string"
+	}
+}
+
+// parseComment splits a YAML head comment block into a free-text
+// description plus the `# +ksbuilder:enum=a,b,c` and `# +ksbuilder:required`
+// directives extension authors can use to enrich the generated schema.
+func parseComment(comment string) (description string, enum []string, required bool) {
+	var descLines []string
+	for _, line := range strings.Split(comment, "\n") {
+		line = strings.TrimSpace(strings.TrimPrefix(line, "#"))
+		switch {
+		case strings.HasPrefix(line, "+ksbuilder:enum="):
+			for _, v := range strings.Split(strings.TrimPrefix(line, "+ksbuilder:enum="), ",") {
+				if v = strings.TrimSpace(v); v != "" {
+					enum = append(enum, v)
+				}
+			}
+		case line == "+ksbuilder:required":
+			required = true
+		case line != "":
+			descLines = append(descLines, line)
+		}
+	}
+	return strings.Join(descLines, " "), enum, required
+}
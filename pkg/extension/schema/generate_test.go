@@ -0,0 +1,98 @@
+package schema
+
+import (
+	"encoding/json"
+	"testing"
+)
+
+func TestGenerate(t *testing.T) {
+	valuesYAML := []byte(`
+# the registry images are pulled from
+# +ksbuilder:enum=docker.io,registry.cn-beijing.aliyuncs.com
+# +ksbuilder:required
+imageRegistry: docker.io
+
+replicaCount: 1
+
+image:
+  tag: latest
+
+tolerations: []
+`)
+
+	out, err := Generate(valuesYAML)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	var got map[string]any
+	if err := json.Unmarshal(out, &got); err != nil {
+		t.Fatalf("Generate produced invalid JSON: %v", err)
+	}
+
+	if got["$schema"] != "http://json-schema.org/draft-07/schema#" {
+		t.Errorf("$schema = %v", got["$schema"])
+	}
+	if got["type"] != "object" {
+		t.Errorf("type = %v, want object", got["type"])
+	}
+
+	properties, ok := got["properties"].(map[string]any)
+	if !ok {
+		t.Fatalf("properties missing or wrong type: %v", got["properties"])
+	}
+
+	imageRegistry, ok := properties["imageRegistry"].(map[string]any)
+	if !ok {
+		t.Fatalf("properties.imageRegistry missing: %v", properties)
+	}
+	if imageRegistry["type"] != "string" {
+		t.Errorf("imageRegistry.type = %v, want string", imageRegistry["type"])
+	}
+	if imageRegistry["description"] != "the registry images are pulled from" {
+		t.Errorf("imageRegistry.description = %v", imageRegistry["description"])
+	}
+	wantEnum := []any{"docker.io", "registry.cn-beijing.aliyuncs.com"}
+	gotEnum, _ := imageRegistry["enum"].([]any)
+	if len(gotEnum) != len(wantEnum) || gotEnum[0] != wantEnum[0] || gotEnum[1] != wantEnum[1] {
+		t.Errorf("imageRegistry.enum = %v, want %v", gotEnum, wantEnum)
+	}
+
+	required, _ := got["required"].([]any)
+	if len(required) != 1 || required[0] != "imageRegistry" {
+		t.Errorf("required = %v, want [imageRegistry]", required)
+	}
+
+	replicaCount, ok := properties["replicaCount"].(map[string]any)
+	if !ok || replicaCount["type"] != "integer" {
+		t.Errorf("replicaCount = %v, want type integer", replicaCount)
+	}
+
+	image, ok := properties["image"].(map[string]any)
+	if !ok || image["type"] != "object" {
+		t.Fatalf("image = %v, want nested object", image)
+	}
+	imageProps, _ := image["properties"].(map[string]any)
+	tag, _ := imageProps["tag"].(map[string]any)
+	if tag["type"] != "string" {
+		t.Errorf("image.tag.type = %v, want string", tag["type"])
+	}
+
+	tolerations, ok := properties["tolerations"].(map[string]any)
+	if !ok || tolerations["type"] != "array" {
+		t.Errorf("tolerations = %v, want type array", tolerations)
+	}
+}
+
+func TestParseComment(t *testing.T) {
+	desc, enum, required := parseComment("line one\nline two\n+ksbuilder:enum=a, b\n+ksbuilder:required")
+	if desc != "line one line two" {
+		t.Errorf("description = %q", desc)
+	}
+	if len(enum) != 2 || enum[0] != "a" || enum[1] != "b" {
+		t.Errorf("enum = %v", enum)
+	}
+	if !required {
+		t.Errorf("required = false, want true")
+	}
+}
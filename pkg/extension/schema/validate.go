@@ -0,0 +1,62 @@
+// Package schema generates and validates the values.schema.json that
+// describes the values.yaml of a KubeSphere extension chart.
+package schema
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+
+	"github.com/santhosh-tekuri/jsonschema/v5"
+)
+
+// Violation is a single values.schema.json validation failure, carrying the
+// JSON Pointer (RFC 6901) of the offending field.
+type Violation struct {
+	Pointer string
+	Message string
+}
+
+// Validate validates values against the draft-07 schema in schemaJSON,
+// returning one Violation per failure found.
+func Validate(schemaJSON []byte, values map[string]interface{}) ([]Violation, error) {
+	compiler := jsonschema.NewCompiler()
+	compiler.Draft = jsonschema.Draft7
+	if err := compiler.AddResource("values.schema.json", bytes.NewReader(schemaJSON)); err != nil {
+		return nil, fmt.Errorf("parse values.schema.json: %w", err)
+	}
+	sch, err := compiler.Compile("values.schema.json")
+	if err != nil {
+		return nil, fmt.Errorf("compile values.schema.json: %w", err)
+	}
+
+	// jsonschema validates decoded JSON values, so round-trip the merged
+	// Helm values through encoding/json to normalize map/slice types.
+	raw, err := json.Marshal(values)
+	if err != nil {
+		return nil, err
+	}
+	var doc interface{}
+	if err := json.Unmarshal(raw, &doc); err != nil {
+		return nil, err
+	}
+
+	if err := sch.Validate(doc); err != nil {
+		verr, ok := err.(*jsonschema.ValidationError)
+		if !ok {
+			return nil, err
+		}
+		return flattenViolations(verr, nil), nil
+	}
+	return nil, nil
+}
+
+func flattenViolations(e *jsonschema.ValidationError, out []Violation) []Violation {
+	if len(e.Causes) == 0 {
+		return append(out, Violation{Pointer: e.InstanceLocation, Message: e.Message})
+	}
+	for _, cause := range e.Causes {
+		out = flattenViolations(cause, out)
+	}
+	return out
+}